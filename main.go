@@ -1,149 +1,208 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"image"
-	"image/color"
 	"image/png"
 	"os"
 	"path/filepath"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
 	"sync"
-	"time"
-)
 
-const (
-	width   = 800
-	height  = 800
-	maxIter = 200
+	"github.com/stasexx/go-mandelbrot/pkg/kernel"
+	"github.com/stasexx/go-mandelbrot/pkg/render"
 )
 
-type ComplexNumber struct {
-	Real, Imag float64
-}
+func main() {
+	cx := flag.Float64("cx", 0, "center real coordinate")
+	cy := flag.Float64("cy", 0, "center imaginary coordinate")
+	scale := flag.Float64("scale", 2, "half-width of the rendered region, in complex-plane units")
+	iter := flag.Int("iter", 200, "maximum iteration count (ignored when -levels is set)")
+	w := flag.Int("w", 800, "image width, in pixels")
+	h := flag.Int("h", 800, "image height, in pixels")
+	out := flag.String("out", "mandelbrot.png", "output PNG path")
+	kernelName := flag.String("kernel", "mandelbrot", "fractal kernel: mandelbrot, julia, burningship, multibrot, nova")
+	jx := flag.Float64("jx", -0.8, "julia kernel: fixed parameter C, real part")
+	jy := flag.Float64("jy", 0.156, "julia kernel: fixed parameter C, imaginary part")
+	degree := flag.Float64("d", 2, "multibrot kernel: exponent D")
+	tracePath := flag.String("trace", "", "write a runtime/trace execution trace to this path")
+	cpuProfilePath := flag.String("cpuprofile", "", "write a runtime/pprof CPU profile to this path")
+	levels := flag.Bool("levels", false, "render the same viewport at easy/normal/hard iteration counts concurrently, each as its own trace task, instead of a single image")
+	flag.Parse()
+
+	kern, err := selectKernel(*kernelName, *jx, *jy, *degree)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
 
-func mandelbrot(c ComplexNumber) color.Color {
-	z := ComplexNumber{}
-	for i := 0; i < maxIter; i++ {
-		z = ComplexNumber{z.Real*z.Real - z.Imag*z.Imag + c.Real, 2*z.Real*z.Imag + c.Imag}
-		if z.Real*z.Real+z.Imag*z.Imag > 4 {
-			return color.Gray{uint8(i % 256)}
+	if *tracePath != "" {
+		stop, err := startTrace(*tracePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
+		defer stop()
 	}
-	return color.Black
-}
-
-func generateMandelbrotSequential(img *image.RGBA, existingImg image.Image) {
-	bounds := img.Bounds()
-	for x := bounds.Min.X; x < bounds.Max.X; x++ {
-		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
-			c := ComplexNumber{
-				Real: float64(x-width/2) / (width / 4),
-				Imag: float64(y-height/2) / (height / 4),
-			}
-			img.Set(x, y, mandelbrot(c))
-			r, g, b, _ := existingImg.At(x, y).RGBA()
-			img.Set(x, y, color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 255})
+	if *cpuProfilePath != "" {
+		stop, err := startCPUProfile(*cpuProfilePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
+		defer stop()
 	}
-}
-
-func generateMandelbrotParallel(img *image.RGBA, existingImg image.Image) {
-	var wg sync.WaitGroup
-	wg.Add(height)
 
-	for x := 0; x < width; x++ {
-		go func(x int) {
-			defer wg.Done()
-			for y := 0; y < height; y++ {
-				c := ComplexNumber{
-					Real: float64(x-width/2) / (width / 4),
-					Imag: float64(y-height/2) / (height / 4),
-				}
-				img.Set(x, y, mandelbrot(c))
-				r, g, b, _ := existingImg.At(x, y).RGBA()
-				img.Set(x, y, color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 255})
-			}
-		}(x)
+	v := render.Viewport{
+		CenterX: *cx,
+		CenterY: *cy,
+		Scale:   *scale,
+		Width:   *w,
+		Height:  *h,
+		MaxIter: *iter,
 	}
 
-	wg.Wait()
-}
-
-func processImage(file string, level string, wg *sync.WaitGroup) {
-	defer wg.Done()
-	fmt.Printf("Processing %s image...\n", level)
-
-	inputFile, err := os.Open(file)
-	if err != nil {
-		fmt.Println("Error opening file:", err)
+	if *levels {
+		if err := renderLevels(context.Background(), v, kern, *out); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
 		return
 	}
-	defer inputFile.Close()
 
-	existingImg, _, err := image.Decode(inputFile)
-	if err != nil {
-		fmt.Println("Error decoding image:", err)
-		return
+	r := &render.Renderer{Kernel: kern}
+	img := r.Render(context.Background(), v)
+
+	if err := saveImage(img, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "Error saving image:", err)
+		os.Exit(1)
 	}
+}
 
-	sequentialImg := image.NewRGBA(existingImg.Bounds())
-	parallelImg := image.NewRGBA(existingImg.Bounds())
+// level pairs a difficulty label with the MaxIter it renders v at.
+type level struct {
+	name    string
+	maxIter int
+}
 
-	copyImage(sequentialImg, existingImg)
-	copyImage(parallelImg, existingImg)
+// renderDifficulties are chosen so the three renders take noticeably
+// different amounts of time, which is what makes their overlap visible
+// in the trace viewer.
+var renderDifficulties = []level{
+	{name: "easy", maxIter: 50},
+	{name: "normal", maxIter: 200},
+	{name: "hard", maxIter: 800},
+}
 
-	startTimeSequential := time.Now()
-	generateMandelbrotSequential(sequentialImg, existingImg)
-	elapsedTimeSequential := time.Since(startTimeSequential)
+// renderLevels renders v at each of renderDifficulties concurrently,
+// naming each render's runtime/trace user task after its level so the
+// trace viewer shows the easy/normal/hard renders overlapping rather
+// than a single "render" task. Each level is saved to its own file,
+// named by levelOutPath: outPath with the level name inserted before
+// its extension.
+func renderLevels(ctx context.Context, v render.Viewport, kern kernel.Kernel, outPath string) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(renderDifficulties))
 
-	sequentialOutputPath := filepath.Join("photos", "result", level, "mandelbrot_sequential.png")
-	saveImage(sequentialImg, sequentialOutputPath)
-	fmt.Printf("%s Sequential: Elapsed time: %s\n", level, elapsedTimeSequential)
+	for i, lv := range renderDifficulties {
+		i, lv := i, lv
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
 
-	startTimeParallel := time.Now()
-	generateMandelbrotParallel(parallelImg, existingImg)
-	elapsedTimeParallel := time.Since(startTimeParallel)
+			ctx, task := trace.NewTask(ctx, "render:"+lv.name)
+			defer task.End()
 
-	parallelOutputPath := filepath.Join("photos", "result", level, "mandelbrot_parallel.png")
-	saveImage(parallelImg, parallelOutputPath)
-	fmt.Printf("%s Parallel: Elapsed time: %s\n", level, elapsedTimeParallel)
-}
+			vLevel := v
+			vLevel.MaxIter = lv.maxIter
+			r := &render.Renderer{Kernel: kern}
+			img := r.Render(ctx, vLevel)
 
-func copyImage(destImg *image.RGBA, srcImg image.Image) {
-	b := destImg.Bounds()
-	for x := b.Min.X; x < b.Max.X; x++ {
-		for y := b.Min.Y; y < b.Max.Y; y++ {
-			destImg.Set(x, y, srcImg.At(x, y))
-		}
+			errs[i] = saveImage(img, levelOutPath(outPath, lv.name))
+		}()
 	}
+	wg.Wait()
+
+	return errors.Join(errs...)
 }
 
-func saveImage(img *image.RGBA, fileName string) {
-	file, err := os.Create(fileName)
+// levelOutPath inserts name before outPath's extension, e.g.
+// levelOutPath("mandelbrot.png", "hard") -> "mandelbrot-hard.png".
+func levelOutPath(outPath, name string) string {
+	ext := filepath.Ext(outPath)
+	base := strings.TrimSuffix(outPath, ext)
+	return base + "-" + name + ext
+}
+
+// startTrace begins a runtime/trace execution trace, writing it to path.
+// The returned func stops the trace and closes the file; callers should
+// defer it.
+func startTrace(path string) (stop func(), err error) {
+	f, err := os.Create(path)
 	if err != nil {
-		fmt.Println("Error creating file:", err)
-		return
+		return nil, fmt.Errorf("creating trace file: %w", err)
 	}
-	defer file.Close()
+	if err := trace.Start(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("starting trace: %w", err)
+	}
+	return func() {
+		trace.Stop()
+		f.Close()
+	}, nil
+}
 
-	err = png.Encode(file, img)
+// startCPUProfile begins a runtime/pprof CPU profile, writing it to path.
+// The returned func stops the profile and closes the file; callers should
+// defer it.
+func startCPUProfile(path string) (stop func(), err error) {
+	f, err := os.Create(path)
 	if err != nil {
-		fmt.Println("Error encoding PNG:", err)
+		return nil, fmt.Errorf("creating CPU profile file: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
 	}
+	return func() {
+		pprof.StopCPUProfile()
+		f.Close()
+	}, nil
 }
 
-func main() {
-	levelFiles := map[string]string{
-		"easy":   "photos/easy.png",
-		"normal": "photos/normal.png",
-		"hard":   "photos/hard.png",
+// selectKernel resolves the -kernel flag, and any kernel-specific flags it
+// requires, to a kernel.Kernel.
+func selectKernel(name string, jx, jy, degree float64) (kernel.Kernel, error) {
+	switch name {
+	case "mandelbrot":
+		return kernel.Mandelbrot{}, nil
+	case "julia":
+		return kernel.Julia{C: complex(jx, jy)}, nil
+	case "burningship":
+		return kernel.BurningShip{}, nil
+	case "multibrot":
+		return kernel.Multibrot{D: degree}, nil
+	case "nova":
+		return kernel.Nova{}, nil
+	default:
+		return nil, fmt.Errorf("unknown kernel %q", name)
 	}
+}
 
-	var wg sync.WaitGroup
-	for level, file := range levelFiles {
-		wg.Add(1)
-		go processImage(file, level, &wg)
+func saveImage(img *image.RGBA, fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
 	}
+	defer file.Close()
 
-	wg.Wait()
+	if err := png.Encode(file, img); err != nil {
+		return fmt.Errorf("encoding PNG: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,14 @@
+package kernel
+
+import "math"
+
+// BurningShip iterates z_{n+1} = (|Re z_n| + i|Im z_n|)^2 + c.
+type BurningShip struct{}
+
+func (BurningShip) Init(c complex128) complex128 { return 0 }
+
+func (BurningShip) Step(z, c complex128) (complex128, bool) {
+	zr, zi := math.Abs(real(z)), math.Abs(imag(z))
+	next := complex(zr*zr-zi*zi, 2*zr*zi) + c
+	return next, real(next)*real(next)+imag(next)*imag(next) > 4
+}
@@ -0,0 +1,14 @@
+package kernel
+
+// Julia iterates z_0=c (the plane coordinate), z_{n+1}=z_n^2+C for a
+// fixed parameter C, tracing the Julia set for that parameter.
+type Julia struct {
+	C complex128
+}
+
+func (Julia) Init(c complex128) complex128 { return c }
+
+func (j Julia) Step(z, c complex128) (complex128, bool) {
+	next := z*z + j.C
+	return next, real(next)*real(next)+imag(next)*imag(next) > 4
+}
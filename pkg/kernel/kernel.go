@@ -0,0 +1,11 @@
+// Package kernel provides pluggable fractal iteration formulas,
+// decoupling the renderer from the Mandelbrot formula specifically.
+package kernel
+
+// Kernel defines a fractal's iteration: Init derives the starting z for
+// a given plane coordinate c, and Step advances z by one iteration,
+// reporting whether it has escaped.
+type Kernel interface {
+	Init(c complex128) complex128
+	Step(z, c complex128) (next complex128, escaped bool)
+}
@@ -0,0 +1,86 @@
+package kernel
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func TestMandelbrotStep(t *testing.T) {
+	m := Mandelbrot{}
+	c := complex(0.3, 0.2)
+	z := m.Init(c)
+	if z != 0 {
+		t.Fatalf("Init(%v) = %v, want 0", c, z)
+	}
+
+	next, escaped := m.Step(z, c)
+	if want := c; next != want {
+		t.Errorf("Step(0, %v) = %v, want %v", c, next, want)
+	}
+	if escaped {
+		t.Errorf("Step(0, %v) reported escaped, want not escaped", c)
+	}
+
+	_, escaped = m.Step(complex(10, 10), c)
+	if !escaped {
+		t.Error("Step with a large z should report escaped")
+	}
+}
+
+func TestJuliaUsesFixedParameter(t *testing.T) {
+	j := Julia{C: complex(-0.8, 0.156)}
+	c := complex(0.1, 0.2)
+
+	if got := j.Init(c); got != c {
+		t.Errorf("Init(%v) = %v, want %v (the plane coordinate)", c, got, c)
+	}
+
+	next, _ := j.Step(c, complex(999, 999))
+	want := c*c + j.C
+	if next != want {
+		t.Errorf("Step ignored the fixed parameter C: got %v, want %v", next, want)
+	}
+}
+
+func TestBurningShipFoldsIntoFirstQuadrant(t *testing.T) {
+	b := BurningShip{}
+	z := complex(-1, -1)
+	c := complex(0, 0)
+
+	next, _ := b.Step(z, c)
+	want := complex(0, 2) // (1+1i)^2 = 2i
+	if next != want {
+		t.Errorf("Step(%v, %v) = %v, want %v", z, c, next, want)
+	}
+}
+
+func TestMultibrotDegree2MatchesMandelbrot(t *testing.T) {
+	m := Mandelbrot{}
+	mb := Multibrot{D: 2}
+	z, c := complex(0.3, -0.4), complex(0.1, 0.2)
+
+	wantNext, wantEscaped := m.Step(z, c)
+	gotNext, gotEscaped := mb.Step(z, c)
+
+	if gotEscaped != wantEscaped {
+		t.Errorf("escaped = %v, want %v", gotEscaped, wantEscaped)
+	}
+	if cmplx.Abs(gotNext-wantNext) > 1e-9 {
+		t.Errorf("Step = %v, want %v", gotNext, wantNext)
+	}
+}
+
+func TestNovaStartsAtARootOfUnity(t *testing.T) {
+	n := Nova{}
+	if got := n.Init(complex(0.1, 0.1)); got != 1 {
+		t.Errorf("Init = %v, want 1", got)
+	}
+	// With c=0, z=1 is already a fixed point of Newton's method for z^3-1.
+	next, escaped := n.Step(1, 0)
+	if next != 1 {
+		t.Errorf("Step(1, 0) = %v, want 1 (fixed point)", next)
+	}
+	if escaped {
+		t.Error("Step at a fixed point should not report escaped")
+	}
+}
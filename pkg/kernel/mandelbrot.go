@@ -0,0 +1,11 @@
+package kernel
+
+// Mandelbrot is the classic z_0=0, z_{n+1}=z_n^2+c kernel.
+type Mandelbrot struct{}
+
+func (Mandelbrot) Init(c complex128) complex128 { return 0 }
+
+func (Mandelbrot) Step(z, c complex128) (complex128, bool) {
+	next := z*z + c
+	return next, real(next)*real(next)+imag(next)*imag(next) > 4
+}
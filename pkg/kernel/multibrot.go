@@ -0,0 +1,16 @@
+package kernel
+
+import "math/cmplx"
+
+// Multibrot generalizes Mandelbrot to z_{n+1} = z_n^D + c for an
+// arbitrary exponent D (D=2 reproduces Mandelbrot).
+type Multibrot struct {
+	D float64
+}
+
+func (Multibrot) Init(c complex128) complex128 { return 0 }
+
+func (m Multibrot) Step(z, c complex128) (complex128, bool) {
+	next := cmplx.Pow(z, complex(m.D, 0)) + c
+	return next, real(next)*real(next)+imag(next)*imag(next) > 4
+}
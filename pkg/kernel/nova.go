@@ -0,0 +1,19 @@
+package kernel
+
+// novaEscapeBound is much larger than Mandelbrot-family kernels use:
+// Nova's Newton's-method iteration oscillates with |z| close to 1 near
+// its roots, so a bound of 4 would misreport convergence as escape.
+const novaEscapeBound = 1e6
+
+// Nova iterates Newton's method applied to z^3-1, perturbed by a plane
+// coordinate c: z_{n+1} = z_n - (z_n^3-1)/(3 z_n^2) + c, starting from a
+// root of z^3=1.
+type Nova struct{}
+
+func (Nova) Init(c complex128) complex128 { return 1 }
+
+func (Nova) Step(z, c complex128) (complex128, bool) {
+	next := z - (z*z*z-1)/(3*z*z) + c
+	mag2 := real(next)*real(next) + imag(next)*imag(next)
+	return next, mag2 > novaEscapeBound*novaEscapeBound
+}
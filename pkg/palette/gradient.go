@@ -0,0 +1,16 @@
+package palette
+
+import "image/color"
+
+// LinearGradient colors escaped points by position through Stops, keyed
+// on the raw (unsmoothed) iteration count.
+type LinearGradient struct {
+	Stops []color.Color
+}
+
+func (p LinearGradient) ColorAt(iter int, zr, zi float64, maxIter int) color.Color {
+	if iter >= maxIter || len(p.Stops) == 0 {
+		return color.Black
+	}
+	return interpolate(p.Stops, float64(iter)/float64(maxIter))
+}
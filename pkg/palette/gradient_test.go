@@ -0,0 +1,30 @@
+package palette
+
+import (
+	"image/color"
+	"testing"
+)
+
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+func TestLinearGradientEndpoints(t *testing.T) {
+	p := LinearGradient{Stops: []color.Color{color.Black, color.White}}
+
+	if got := p.ColorAt(0, 0, 0, 100); !sameColor(got, color.Black) {
+		t.Errorf("ColorAt(0) = %v, want black", got)
+	}
+	if got := p.ColorAt(99, 0, 0, 100); sameColor(got, color.Black) {
+		t.Errorf("ColorAt(maxIter-1) = %v, want a color closer to the far stop", got)
+	}
+}
+
+func TestLinearGradientNoStopsIsBlack(t *testing.T) {
+	p := LinearGradient{}
+	if got := p.ColorAt(5, 0, 0, 100); !sameColor(got, color.Black) {
+		t.Errorf("ColorAt with no stops = %v, want black", got)
+	}
+}
@@ -0,0 +1,14 @@
+package palette
+
+import "image/color"
+
+// Grayscale reproduces the renderer's original coloring: escape-time
+// iteration count modulo 256, banded rather than smoothly interpolated.
+type Grayscale struct{}
+
+func (Grayscale) ColorAt(iter int, zr, zi float64, maxIter int) color.Color {
+	if iter >= maxIter {
+		return color.Black
+	}
+	return color.Gray{Y: uint8(iter % 256)}
+}
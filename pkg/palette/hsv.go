@@ -0,0 +1,61 @@
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// DefaultCycleLength is the number of iterations per full hue rotation
+// used by HSVCycle when CycleLength is zero.
+const DefaultCycleLength = 64
+
+// HSVCycle colors escaped points by cycling hue with iteration count,
+// wrapping every CycleLength iterations.
+type HSVCycle struct {
+	// CycleLength is the number of iterations per full hue rotation.
+	// Zero selects DefaultCycleLength.
+	CycleLength int
+}
+
+func (p HSVCycle) ColorAt(iter int, zr, zi float64, maxIter int) color.Color {
+	if iter >= maxIter {
+		return color.Black
+	}
+	cycle := p.CycleLength
+	if cycle <= 0 {
+		cycle = DefaultCycleLength
+	}
+	hue := 360 * float64(iter%cycle) / float64(cycle)
+	return hsvToRGB(hue, 1, 1)
+}
+
+// hsvToRGB converts a color given as hue in degrees ([0,360)) and
+// saturation/value in [0,1] to RGB.
+func hsvToRGB(h, s, v float64) color.Color {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return color.RGBA{
+		R: uint8((r + m) * 255),
+		G: uint8((g + m) * 255),
+		B: uint8((b + m) * 255),
+		A: 255,
+	}
+}
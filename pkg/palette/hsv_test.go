@@ -0,0 +1,19 @@
+package palette
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGrayscaleAtMaxIterIsBlack(t *testing.T) {
+	if got := (Grayscale{}).ColorAt(200, 1, 1, 200); !sameColor(got, color.Black) {
+		t.Errorf("ColorAt at maxIter = %v, want black", got)
+	}
+}
+
+func TestHSVCycleWrapsAtCycleLength(t *testing.T) {
+	p := HSVCycle{CycleLength: 10}
+	if got, want := p.ColorAt(0, 0, 0, 100), p.ColorAt(10, 0, 0, 100); got != want {
+		t.Errorf("ColorAt(0) = %v, ColorAt(cycle) = %v, want equal", got, want)
+	}
+}
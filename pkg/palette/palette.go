@@ -0,0 +1,43 @@
+// Package palette maps Mandelbrot escape-time data to colors, decoupling
+// the renderer from any one coloring scheme.
+package palette
+
+import "image/color"
+
+// Palette maps the result of iterating a point to a color. iter is the
+// number of iterations completed before the point escaped (or maxIter if
+// it never did); zr, zi are the real and imaginary parts of the orbit's
+// final value.
+type Palette interface {
+	ColorAt(iter int, zr, zi float64, maxIter int) color.Color
+}
+
+// lerpColor linearly interpolates between a and b at t in [0, 1].
+func lerpColor(a, b color.Color, t float64) color.Color {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	lerp := func(x, y uint32) uint8 {
+		return uint8((float64(x) + (float64(y)-float64(x))*t) / 257)
+	}
+	return color.RGBA{
+		R: lerp(ar, br),
+		G: lerp(ag, bg),
+		B: lerp(ab, bb),
+		A: lerp(aa, ba),
+	}
+}
+
+// interpolate maps t (expected in [0, 1)) onto a position along stops,
+// linearly blending between the two nearest stops.
+func interpolate(stops []color.Color, t float64) color.Color {
+	if len(stops) == 1 {
+		return stops[0]
+	}
+	segments := len(stops) - 1
+	pos := t * float64(segments)
+	i := int(pos)
+	if i >= segments {
+		i = segments - 1
+	}
+	return lerpColor(stops[i], stops[i+1], pos-float64(i))
+}
@@ -0,0 +1,44 @@
+package palette_test
+
+import (
+	"context"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+	"github.com/stasexx/go-mandelbrot/pkg/render"
+)
+
+func checksum(img *image.RGBA) uint64 {
+	h := fnv.New64a()
+	h.Write(img.Pix)
+	return h.Sum64()
+}
+
+func TestPaletteGoldenImages(t *testing.T) {
+	v := render.Viewport{CenterX: -0.5, CenterY: 0, Scale: 1.5, Width: 40, Height: 40, MaxIter: 100}
+	stops := []color.Color{color.Black, color.RGBA{R: 255, G: 165, B: 0, A: 255}, color.White}
+
+	cases := []struct {
+		name string
+		pal  palette.Palette
+		want uint64
+	}{
+		{"grayscale", palette.Grayscale{}, 7278032591094481384},
+		{"hsv cycle", palette.HSVCycle{}, 5837215983549138731},
+		{"linear gradient", palette.LinearGradient{Stops: stops}, 11400808755247475683},
+		{"smooth escape", palette.SmoothEscape{Stops: stops}, 13704351447594628721},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &render.Renderer{Palette: tc.pal}
+			got := checksum(r.Render(context.Background(), v))
+			if got != tc.want {
+				t.Errorf("%s: checksum = %d, want %d (image changed)", tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package palette
+
+import (
+	"image/color"
+	"math"
+)
+
+// SmoothEscape colors escaped points using the continuous (normalized)
+// iteration count, eliminating the banding that comes from coloring by
+// raw integer iteration count.
+type SmoothEscape struct {
+	Stops []color.Color
+
+	// CycleLength is the number of mu-units spanned by one full pass
+	// through Stops. Zero selects len(Stops).
+	CycleLength float64
+}
+
+func (p SmoothEscape) ColorAt(iter int, zr, zi float64, maxIter int) color.Color {
+	if iter >= maxIter || len(p.Stops) == 0 {
+		return color.Black
+	}
+
+	modulus := math.Sqrt(zr*zr + zi*zi)
+	mu := float64(iter) + 1 - math.Log2(math.Log(modulus))
+
+	cycle := p.CycleLength
+	if cycle <= 0 {
+		cycle = float64(len(p.Stops))
+	}
+	t := math.Mod(mu, cycle) / cycle
+	if t < 0 {
+		t += 1
+	}
+	return interpolate(p.Stops, t)
+}
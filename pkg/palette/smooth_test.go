@@ -0,0 +1,23 @@
+package palette
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestSmoothEscapeAtMaxIterIsBlack(t *testing.T) {
+	p := SmoothEscape{Stops: []color.Color{color.Black, color.White}}
+	if got := p.ColorAt(100, 3, 0, 100); !sameColor(got, color.Black) {
+		t.Errorf("ColorAt at maxIter = %v, want black", got)
+	}
+}
+
+func TestSmoothEscapeVariesContinuously(t *testing.T) {
+	p := SmoothEscape{Stops: []color.Color{color.Black, color.White}}
+
+	a := p.ColorAt(10, 2.0, 0, 1000)
+	b := p.ColorAt(10, 2.01, 0, 1000)
+	if a == b {
+		t.Errorf("expected a small change in |z| to change the interpolated color")
+	}
+}
@@ -0,0 +1,335 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"runtime"
+	"runtime/trace"
+	"sync"
+
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// DefaultGlitchTolerance is the epsilon used by DeepRenderer's glitch
+// test, |Z_n+dz_n| < epsilon*|Z_n|, when DeepViewport.GlitchTolerance is
+// zero.
+const DefaultGlitchTolerance = 1e-6
+
+// DefaultSeriesTolerance bounds the cubic-term error accepted when
+// choosing how many iterations DeepRenderer's series approximation may
+// skip, when DeepViewport.SeriesTolerance is zero.
+const DefaultSeriesTolerance = 1e-6
+
+// DeepViewport describes a deep-zoom render. Unlike Viewport, the center
+// is given as a decimal string rather than float64, since at scales
+// where DeepRenderer is useful the center needs more significant digits
+// than float64 can hold; Scale itself stays a float64 because pixel
+// offsets from the center are always small relative to it.
+type DeepViewport struct {
+	CenterX, CenterY string
+	Scale            float64
+	Width, Height    int
+	MaxIter          int
+
+	// GlitchTolerance is epsilon in the glitch test |Z_n+dz_n| <
+	// epsilon*|Z_n|. Zero selects DefaultGlitchTolerance.
+	GlitchTolerance float64
+
+	// SeriesDegree enables series approximation when positive: the first
+	// iterations of every pixel are skipped by evaluating a degree-3
+	// polynomial in dc instead of iterating the perturbation recurrence.
+	// Zero disables series approximation.
+	SeriesDegree int
+
+	// SeriesTolerance bounds the series approximation's cubic-term
+	// error. Zero selects DefaultSeriesTolerance. Ignored when
+	// SeriesDegree is zero.
+	SeriesTolerance float64
+
+	// Precision is the math/big.Float mantissa precision, in bits, used
+	// to compute reference orbits. Zero selects DefaultPrecision.
+	Precision uint
+}
+
+func (v DeepViewport) precision() uint {
+	if v.Precision == 0 {
+		return DefaultPrecision
+	}
+	return v.Precision
+}
+
+func (v DeepViewport) glitchTolerance() float64 {
+	if v.GlitchTolerance == 0 {
+		return DefaultGlitchTolerance
+	}
+	return v.GlitchTolerance
+}
+
+func (v DeepViewport) seriesTolerance() float64 {
+	if v.SeriesTolerance == 0 {
+		return DefaultSeriesTolerance
+	}
+	return v.SeriesTolerance
+}
+
+// deltaAt returns the pixel offset from the viewport center, dc, which
+// perturbation theory evaluates in plain float64 regardless of zoom
+// depth.
+func (v DeepViewport) deltaAt(x, y int) complex128 {
+	dr := (float64(x)/float64(v.Width) - 0.5) * 2 * v.Scale
+	di := (float64(y)/float64(v.Height) - 0.5) * 2 * v.Scale
+	return complex(dr, di)
+}
+
+// DeepRenderer renders deep zooms using perturbation theory: a single
+// high-precision reference orbit is computed once with math/big.Float,
+// and every other pixel is evaluated as a float64 delta around that
+// orbit, which is orders of magnitude cheaper than iterating every pixel
+// in big.Float. Pixels whose delta loses too much precision relative to
+// the reference ("glitches", by Pauldelbrot's criterion) are
+// re-evaluated against a new reference orbit chosen from the glitched
+// cluster.
+type DeepRenderer struct {
+	// Workers is the number of goroutines used to compute tiles. Zero
+	// selects runtime.NumCPU().
+	Workers int
+
+	// Palette colors each pixel's escape-time result. Nil selects
+	// palette.Grayscale{}, matching Renderer's default.
+	Palette palette.Palette
+}
+
+func (r *DeepRenderer) workers() int {
+	if r.Workers > 0 {
+		return r.Workers
+	}
+	return runtime.NumCPU()
+}
+
+type pixelCoord struct{ x, y int }
+
+// Render computes the Mandelbrot image described by v.
+func (r *DeepRenderer) Render(ctx context.Context, v DeepViewport) (*image.RGBA, error) {
+	ctx, task := trace.NewTask(ctx, "deep-render")
+	defer task.End()
+
+	prec := v.precision()
+
+	cx, _, err := big.ParseFloat(v.CenterX, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CenterX: %w", err)
+	}
+	cy, _, err := big.ParseFloat(v.CenterY, 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CenterY: %w", err)
+	}
+
+	orbit := referenceOrbit(cx, cy, prec, v.MaxIter)
+
+	var A, B, C []complex128
+	skipN := 0
+	if v.SeriesDegree > 0 {
+		A, B, C = seriesCoefficients(orbit)
+		maxDc := v.Scale * math.Sqrt2
+		skipN = seriesSkipLimit(C, maxDc, v.seriesTolerance())
+	}
+
+	pal := r.Palette
+	if pal == nil {
+		pal = palette.Grayscale{}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, v.Width, v.Height))
+
+	tiles := tilesFor(v.Width, v.Height, DefaultTileSize)
+	work := make(chan tile, len(tiles))
+	for _, t := range tiles {
+		work <- t
+	}
+	close(work)
+
+	var mu sync.Mutex
+	var glitched []pixelCoord
+
+	var wg sync.WaitGroup
+	workers := r.workers()
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for t := range work {
+				if ctx.Err() != nil {
+					return
+				}
+				trace.WithRegion(ctx, "tile", func() {
+					for x := t.minX; x < t.maxX; x++ {
+						for y := t.minY; y < t.maxY; y++ {
+							dc := v.deltaAt(x, y)
+							iter, zr, zi, glitch := perturb(dc, orbit, A, B, C, skipN, v.MaxIter, v.glitchTolerance())
+							if glitch {
+								mu.Lock()
+								glitched = append(glitched, pixelCoord{x, y})
+								mu.Unlock()
+								continue
+							}
+							img.Set(x, y, pal.ColorAt(iter, zr, zi, v.MaxIter))
+						}
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return img, err
+	}
+
+	if len(glitched) > 0 {
+		if err := r.rerender(img, glitched, cx, cy, prec, v, pal); err != nil {
+			return img, err
+		}
+	}
+
+	return img, nil
+}
+
+// rerender picks a new reference orbit at the first recorded glitched
+// pixel and re-evaluates every glitched pixel against it, per
+// Pauldelbrot's glitch-fixing criterion.
+func (r *DeepRenderer) rerender(img *image.RGBA, glitched []pixelCoord, cx, cy *big.Float, prec uint, v DeepViewport, pal palette.Palette) error {
+	seed := glitched[0]
+	seedDc := v.deltaAt(seed.x, seed.y)
+
+	newCx := new(big.Float).SetPrec(prec).Add(cx, big.NewFloat(real(seedDc)))
+	newCy := new(big.Float).SetPrec(prec).Add(cy, big.NewFloat(imag(seedDc)))
+	newOrbit := referenceOrbit(newCx, newCy, prec, v.MaxIter)
+
+	for _, p := range glitched {
+		dc := v.deltaAt(p.x, p.y) - seedDc
+		iter, zr, zi, glitch := perturb(dc, newOrbit, nil, nil, nil, 0, v.MaxIter, v.glitchTolerance())
+		if glitch {
+			// The new reference still doesn't resolve this pixel; color
+			// it from its last known (glitched) state rather than loop
+			// indefinitely re-referencing.
+			iter = len(newOrbit) - 1
+		}
+		img.Set(p.x, p.y, pal.ColorAt(iter, zr, zi, v.MaxIter))
+	}
+	return nil
+}
+
+// referenceOrbit computes Z_0=0, Z_{n+1}=Z_n^2+c at precision prec,
+// stopping at maxIter or when the orbit escapes, and returns each Z_n
+// rounded to float64 (sufficient precision for perturbation deltas,
+// which only ever need to track small offsets from this orbit).
+func referenceOrbit(cx, cy *big.Float, prec uint, maxIter int) []complex128 {
+	orbit := make([]complex128, 1, maxIter+1)
+	orbit[0] = 0
+
+	zr := new(big.Float).SetPrec(prec)
+	zi := new(big.Float).SetPrec(prec)
+	zr2 := new(big.Float).SetPrec(prec)
+	zi2 := new(big.Float).SetPrec(prec)
+	mag := new(big.Float).SetPrec(prec)
+	nextZr := new(big.Float).SetPrec(prec)
+	nextZi := new(big.Float).SetPrec(prec)
+	four := big.NewFloat(4)
+	two := big.NewFloat(2)
+
+	for i := 0; i < maxIter; i++ {
+		zr2.Mul(zr, zr)
+		zi2.Mul(zi, zi)
+
+		nextZr.Sub(zr2, zi2)
+		nextZr.Add(nextZr, cx)
+
+		nextZi.Mul(zr, zi)
+		nextZi.Mul(nextZi, two)
+		nextZi.Add(nextZi, cy)
+
+		zr.Set(nextZr)
+		zi.Set(nextZi)
+
+		zrf, _ := zr.Float64()
+		zif, _ := zi.Float64()
+		orbit = append(orbit, complex(zrf, zif))
+
+		zr2.Mul(zr, zr)
+		zi2.Mul(zi, zi)
+		mag.Add(zr2, zi2)
+		if mag.Cmp(four) > 0 {
+			break
+		}
+	}
+	return orbit
+}
+
+// seriesCoefficients computes, for each n, the coefficients of the
+// degree-3 Taylor expansion dz_n = A_n*dc + B_n*dc^2 + C_n*dc^3 of the
+// perturbation recurrence around dc=0.
+func seriesCoefficients(orbit []complex128) (A, B, C []complex128) {
+	n := len(orbit)
+	A = make([]complex128, n)
+	B = make([]complex128, n)
+	C = make([]complex128, n)
+	for i := 0; i < n-1; i++ {
+		Z := orbit[i]
+		A[i+1] = 2*Z*A[i] + 1
+		B[i+1] = 2*Z*B[i] + A[i]*A[i]
+		C[i+1] = 2*Z*C[i] + 2*A[i]*B[i]
+	}
+	return A, B, C
+}
+
+// seriesSkipLimit returns the largest n for which the series
+// approximation's cubic term, evaluated at the worst-case (largest) dc
+// in the viewport, stays below tol. Skipping to this n is safe for every
+// pixel, since every pixel's dc is no larger than maxDc.
+func seriesSkipLimit(C []complex128, maxDc, tol float64) int {
+	skip := 0
+	for n := 1; n < len(C); n++ {
+		if cmplx.Abs(C[n])*maxDc*maxDc*maxDc >= tol {
+			break
+		}
+		skip = n
+	}
+	return skip
+}
+
+// perturb evaluates the perturbation recurrence dz_{n+1} =
+// 2*Z_n*dz_n + dz_n^2 + dc starting from dz_skipN (estimated via the
+// series coefficients, if provided) up to orbit's length or escape,
+// returning the completed iteration count and final z = Z_n+dz_n. An
+// escape is counted as iterateAt counts it: n when Z_{n+1}+dz_{n+1} is
+// the first value past the escape radius. glitch
+// reports whether the orbit failed Pauldelbrot's criterion,
+// |Z_n+dz_n| < tolerance*|Z_n|, before escaping or reaching maxIter.
+func perturb(dc complex128, orbit, A, B, C []complex128, skipN, maxIter int, tolerance float64) (iter int, zr, zi float64, glitch bool) {
+	var dz complex128
+	if skipN > 0 && A != nil {
+		dz = A[skipN]*dc + B[skipN]*dc*dc + C[skipN]*dc*dc*dc
+	}
+
+	for n := skipN; n < len(orbit)-1; n++ {
+		Z := orbit[n]
+		dz = 2*Z*dz + dz*dz + dc
+
+		Znext := orbit[n+1]
+		z := Znext + dz
+
+		if cmplx.Abs(z) < tolerance*cmplx.Abs(Znext) {
+			return n + 1, real(z), imag(z), true
+		}
+		if real(z)*real(z)+imag(z)*imag(z) > 4 {
+			return n, real(z), imag(z), false
+		}
+	}
+
+	z := orbit[len(orbit)-1] + dz
+	return maxIter, real(z), imag(z), false
+}
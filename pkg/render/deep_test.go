@@ -0,0 +1,76 @@
+package render
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestReferenceOrbitMatchesFloat64Iteration(t *testing.T) {
+	cx, cy := big.NewFloat(-0.5), big.NewFloat(0)
+	orbit := referenceOrbit(cx, cy, 256, 100)
+
+	var zr, zi float64
+	for i := 0; i < 100; i++ {
+		zr, zi = zr*zr-zi*zi-0.5, 2*zr*zi
+		got := orbit[i+1]
+		if d := real(got) - zr; d > 1e-9 || d < -1e-9 {
+			t.Fatalf("iter %d: real part = %v, want %v", i, real(got), zr)
+		}
+		if d := imag(got) - zi; d > 1e-9 || d < -1e-9 {
+			t.Fatalf("iter %d: imag part = %v, want %v", i, imag(got), zi)
+		}
+	}
+}
+
+func TestDeepRendererMatchesDirectFloat64AtShallowZoom(t *testing.T) {
+	v := DeepViewport{CenterX: "-0.5", CenterY: "0", Scale: 0.01, Width: 20, Height: 20, MaxIter: 200}
+	got, err := (&DeepRenderer{}).Render(context.Background(), v)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := Render(Viewport{CenterX: -0.5, CenterY: 0, Scale: 0.01, Width: 20, Height: 20, MaxIter: 200})
+
+	mismatched := 0
+	bounds := want.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if got.At(x, y) != want.At(x, y) {
+				mismatched++
+			}
+		}
+	}
+
+	total := bounds.Dx() * bounds.Dy()
+	if mismatched > total/20 {
+		t.Fatalf("%d/%d pixels differ from the direct float64 oracle, want < 5%%", mismatched, total)
+	}
+}
+
+func TestSeriesSkipNeverExceedsEscapeIteration(t *testing.T) {
+	// c=2 sits just outside the set and escapes almost immediately,
+	// giving a short, exactly-known orbit.
+	orbit := referenceOrbit(big.NewFloat(2), big.NewFloat(0), 64, 50)
+	escapeIter := len(orbit) - 1
+
+	_, _, C := seriesCoefficients(orbit)
+	skip := seriesSkipLimit(C, 0.1, 1e-6)
+
+	if skip > escapeIter {
+		t.Fatalf("series skip = %d, must not exceed the escape iteration %d", skip, escapeIter)
+	}
+}
+
+func TestPerturbFlagsGlitchOnCancellation(t *testing.T) {
+	// dz_1 = dc exactly (since dz_0=Z_0=0), so choosing dc=-Z_1 makes
+	// the true value Z_1+dz_1 cancel to zero: a large reference with a
+	// vanishing true value is the textbook glitch.
+	orbit := []complex128{0, 5}
+	dc := complex(-5, 0)
+
+	_, _, _, glitch := perturb(dc, orbit, nil, nil, nil, 0, 50, 0.5)
+	if !glitch {
+		t.Fatal("expected perturb to flag a glitch for a canceling delta")
+	}
+}
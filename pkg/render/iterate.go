@@ -0,0 +1,23 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/stasexx/go-mandelbrot/pkg/kernel"
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// iterateAt runs k over the plane coordinate (re, im) for at most maxIter
+// steps and colors the result with pal, using float64 arithmetic.
+func iterateAt(k kernel.Kernel, re, im float64, maxIter int, pal palette.Palette) color.Color {
+	c := complex(re, im)
+	z := k.Init(c)
+	for i := 0; i < maxIter; i++ {
+		next, escaped := k.Step(z, c)
+		if escaped {
+			return pal.ColorAt(i, real(next), imag(next), maxIter)
+		}
+		z = next
+	}
+	return pal.ColorAt(maxIter, real(z), imag(z), maxIter)
+}
@@ -0,0 +1,152 @@
+// Package render computes Mandelbrot set images using a bounded worker
+// pool: the image is partitioned into fixed-size tiles, and a small
+// number of worker goroutines pull tiles from a shared queue until it is
+// drained. This avoids the goroutine-per-pixel (or per-column) fan-out
+// that causes excessive scheduler and GC pressure at larger resolutions.
+package render
+
+import (
+	"context"
+	"image"
+	"runtime"
+	"runtime/trace"
+	"sync"
+
+	"github.com/stasexx/go-mandelbrot/pkg/kernel"
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// DefaultTileSize is the edge length, in pixels, of each unit of work
+// handed to a worker when Renderer.TileSize is zero.
+const DefaultTileSize = 32
+
+// tile describes a rectangular region of the image, in the half-open
+// interval [minX, maxX) x [minY, maxY).
+type tile struct {
+	minX, minY, maxX, maxY int
+}
+
+// Renderer computes Mandelbrot images with a producer/consumer worker
+// pool: tiles are pushed onto a buffered channel and pulled by a fixed
+// number of workers until the channel is drained.
+type Renderer struct {
+	// Workers is the number of goroutines used to compute tiles. Zero
+	// selects runtime.NumCPU().
+	Workers int
+
+	// TileSize is the edge length, in pixels, of each square tile of
+	// work. Zero selects DefaultTileSize.
+	TileSize int
+
+	// Palette colors each pixel's escape-time result. Nil selects
+	// palette.Grayscale{}, matching the renderer's original output.
+	Palette palette.Palette
+
+	// Kernel selects the fractal formula iterated at each pixel. Nil
+	// selects kernel.Mandelbrot{}, matching the renderer's original
+	// output. The math/big.Float deep-zoom path below DeepZoomThreshold
+	// is only implemented for kernel.Mandelbrot{}; other kernels always
+	// render in float64.
+	Kernel kernel.Kernel
+
+	// Progress, if set, is called from RenderStream after each tile is
+	// sent, reporting how many of the total tiles have completed.
+	// RenderStream serializes calls to Progress, so it is always called
+	// from one goroutine at a time even though tiles complete on
+	// multiple worker goroutines.
+	Progress func(completed, total int)
+}
+
+// Render computes the Mandelbrot image described by v, returning early
+// with a partially-filled image if ctx is cancelled.
+func (r *Renderer) Render(ctx context.Context, v Viewport) *image.RGBA {
+	ctx, task := trace.NewTask(ctx, "render")
+	defer task.End()
+
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	tileSize := r.TileSize
+	if tileSize <= 0 {
+		tileSize = DefaultTileSize
+	}
+	pal := r.Palette
+	if pal == nil {
+		pal = palette.Grayscale{}
+	}
+	kern := r.Kernel
+	if kern == nil {
+		kern = kernel.Mandelbrot{}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, v.Width, v.Height))
+	tiles := tilesFor(v.Width, v.Height, tileSize)
+
+	work := make(chan tile, len(tiles))
+	for _, t := range tiles {
+		work <- t
+	}
+	close(work)
+
+	_, mandelbrotKernel := kern.(kernel.Mandelbrot)
+	deep := mandelbrotKernel && v.Scale < DeepZoomThreshold
+	prec := v.precision()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for t := range work {
+				if ctx.Err() != nil {
+					return
+				}
+				trace.WithRegion(ctx, "tile", func() {
+					if deep {
+						renderTileBig(img, t, v, prec, pal)
+					} else {
+						renderTile(img, t, v, kern, pal)
+					}
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	return img
+}
+
+// tilesFor partitions a width x height image into tileSize x tileSize
+// tiles, left-to-right, top-to-bottom, with the final tile in each row
+// and column clipped to the image bounds.
+func tilesFor(width, height, tileSize int) []tile {
+	var tiles []tile
+	for y := 0; y < height; y += tileSize {
+		for x := 0; x < width; x += tileSize {
+			tiles = append(tiles, tile{
+				minX: x,
+				minY: y,
+				maxX: min(x+tileSize, width),
+				maxY: min(y+tileSize, height),
+			})
+		}
+	}
+	return tiles
+}
+
+func renderTile(img *image.RGBA, t tile, v Viewport, kern kernel.Kernel, pal palette.Palette) {
+	for x := t.minX; x < t.maxX; x++ {
+		for y := t.minY; y < t.maxY; y++ {
+			re, im := v.coordAt(x, y)
+			img.Set(x, y, iterateAt(kern, re, im, v.MaxIter, pal))
+		}
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,117 @@
+package render
+
+import (
+	"context"
+	"image"
+	"strconv"
+	"testing"
+
+	"github.com/stasexx/go-mandelbrot/pkg/kernel"
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// renderSequential is a reference implementation that computes the same
+// image one pixel at a time, in a single goroutine. It exists so tests
+// can assert the worker-pool renderer is pixel-for-pixel identical to a
+// known-correct baseline.
+func renderSequential(v Viewport) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, v.Width, v.Height))
+	for x := 0; x < v.Width; x++ {
+		for y := 0; y < v.Height; y++ {
+			re, im := v.coordAt(x, y)
+			img.Set(x, y, iterateAt(kernel.Mandelbrot{}, re, im, v.MaxIter, palette.Grayscale{}))
+		}
+	}
+	return img
+}
+
+func imagesEqual(a, b *image.RGBA) bool {
+	if a.Bounds() != b.Bounds() {
+		return false
+	}
+	bounds := a.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if a.At(x, y) != b.At(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestRenderMatchesSequential(t *testing.T) {
+	v := Viewport{Scale: 2, Width: 200, Height: 150, MaxIter: 100}
+	want := renderSequential(v)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		for _, tileSize := range []int{8, 32, 64, 256} {
+			r := &Renderer{Workers: workers, TileSize: tileSize}
+			got := r.Render(context.Background(), v)
+			if !imagesEqual(want, got) {
+				t.Errorf("workers=%d tileSize=%d: render mismatch vs sequential", workers, tileSize)
+			}
+		}
+	}
+}
+
+func TestRenderUsesKernel(t *testing.T) {
+	v := Viewport{Scale: 2, Width: 64, Height: 64, MaxIter: 100}
+
+	mandelbrot := (&Renderer{Kernel: kernel.Mandelbrot{}}).Render(context.Background(), v)
+	julia := (&Renderer{Kernel: kernel.Julia{C: complex(-0.8, 0.156)}}).Render(context.Background(), v)
+
+	if imagesEqual(mandelbrot, julia) {
+		t.Error("Renderer with a Julia kernel produced the same image as Mandelbrot")
+	}
+
+	defaultRender := (&Renderer{}).Render(context.Background(), v)
+	if !imagesEqual(mandelbrot, defaultRender) {
+		t.Error("Renderer with no Kernel set should default to kernel.Mandelbrot{}")
+	}
+}
+
+func TestTilesForCoversImageExactlyOnce(t *testing.T) {
+	width, height, tileSize := 101, 77, 32
+	covered := make([][]bool, height)
+	for i := range covered {
+		covered[i] = make([]bool, width)
+	}
+
+	for _, tl := range tilesFor(width, height, tileSize) {
+		for x := tl.minX; x < tl.maxX; x++ {
+			for y := tl.minY; y < tl.maxY; y++ {
+				if covered[y][x] {
+					t.Fatalf("pixel (%d,%d) covered by more than one tile", x, y)
+				}
+				covered[y][x] = true
+			}
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if !covered[y][x] {
+				t.Fatalf("pixel (%d,%d) not covered by any tile", x, y)
+			}
+		}
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	v := Viewport{Scale: 2, Width: 400, Height: 400, MaxIter: 200}
+	for _, workers := range []int{1, 2, 4, 8} {
+		workers := workers
+		b.Run(benchName(workers), func(b *testing.B) {
+			r := &Renderer{Workers: workers}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r.Render(context.Background(), v)
+			}
+		})
+	}
+}
+
+func benchName(workers int) string {
+	return "workers=" + strconv.Itoa(workers)
+}
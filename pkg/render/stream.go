@@ -0,0 +1,113 @@
+package render
+
+import (
+	"context"
+	"image"
+	"runtime"
+	"runtime/trace"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stasexx/go-mandelbrot/pkg/kernel"
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// Tile is a rendered rectangular region of an image, delivered
+// incrementally by RenderStream. Bounds gives the tile's position within
+// the full image; Image holds just that tile's pixels.
+type Tile struct {
+	Bounds image.Rectangle
+	Image  *image.RGBA
+}
+
+// RenderStream renders v using a Renderer with default worker-pool
+// settings. It is a convenience wrapper for callers that don't need to
+// tune Workers, TileSize, or Palette.
+func RenderStream(ctx context.Context, v Viewport, out chan<- Tile) error {
+	return (&Renderer{}).RenderStream(ctx, v, out)
+}
+
+// RenderStream renders v the same way Render does, but delivers each
+// tile on out as soon as it is computed instead of waiting for the full
+// image, and checks ctx between tiles so a long render can be cancelled
+// or time-limited and displayed incrementally. RenderStream closes out
+// before returning, whether it completes or is cancelled; each send on
+// out blocks until it is received or ctx is done. If Progress is set, it
+// is called after each tile is sent; calls are serialized, so Progress itself need not be
+// goroutine-safe even though tiles are computed concurrently.
+func (r *Renderer) RenderStream(ctx context.Context, v Viewport, out chan<- Tile) error {
+	defer close(out)
+
+	ctx, task := trace.NewTask(ctx, "render-stream")
+	defer task.End()
+
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	tileSize := r.TileSize
+	if tileSize <= 0 {
+		tileSize = DefaultTileSize
+	}
+	pal := r.Palette
+	if pal == nil {
+		pal = palette.Grayscale{}
+	}
+	kern := r.Kernel
+	if kern == nil {
+		kern = kernel.Mandelbrot{}
+	}
+
+	tiles := tilesFor(v.Width, v.Height, tileSize)
+	total := len(tiles)
+
+	work := make(chan tile, total)
+	for _, t := range tiles {
+		work <- t
+	}
+	close(work)
+
+	_, mandelbrotKernel := kern.(kernel.Mandelbrot)
+	deep := mandelbrotKernel && v.Scale < DeepZoomThreshold
+	prec := v.precision()
+
+	var completed int32
+	var progressMu sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for t := range work {
+				if ctx.Err() != nil {
+					return
+				}
+
+				img := image.NewRGBA(image.Rect(t.minX, t.minY, t.maxX, t.maxY))
+				trace.WithRegion(ctx, "tile", func() {
+					if deep {
+						renderTileBig(img, t, v, prec, pal)
+					} else {
+						renderTile(img, t, v, kern, pal)
+					}
+				})
+
+				select {
+				case out <- Tile{Bounds: img.Bounds(), Image: img}:
+				case <-ctx.Done():
+					return
+				}
+
+				if r.Progress != nil {
+					progressMu.Lock()
+					n := atomic.AddInt32(&completed, 1)
+					r.Progress(int(n), total)
+					progressMu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
@@ -0,0 +1,107 @@
+package render
+
+import (
+	"context"
+	"image"
+	"sync"
+	"testing"
+	"time"
+)
+
+func drain(out <-chan Tile) []Tile {
+	var tiles []Tile
+	for t := range out {
+		tiles = append(tiles, t)
+	}
+	return tiles
+}
+
+func TestRenderStreamReassemblesToSameImage(t *testing.T) {
+	v := Viewport{Scale: 2, Width: 100, Height: 80, MaxIter: 100}
+	want := Render(v)
+
+	out := make(chan Tile)
+	var tiles []Tile
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tiles = drain(out)
+	}()
+
+	if err := RenderStream(context.Background(), v, out); err != nil {
+		t.Fatalf("RenderStream: %v", err)
+	}
+	wg.Wait()
+
+	got := image.NewRGBA(want.Bounds())
+	for _, tile := range tiles {
+		b := tile.Bounds
+		for x := b.Min.X; x < b.Max.X; x++ {
+			for y := b.Min.Y; y < b.Max.Y; y++ {
+				got.Set(x, y, tile.Image.At(x, y))
+			}
+		}
+	}
+
+	if !imagesEqual(want, got) {
+		t.Fatal("image reassembled from stream tiles does not match Render")
+	}
+}
+
+func TestRenderStreamRespectsCancellation(t *testing.T) {
+	v := Viewport{Scale: 2, Width: 2000, Height: 2000, MaxIter: 5000}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan Tile)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range out {
+			cancel()
+		}
+	}()
+
+	err := (&Renderer{Workers: 1}).RenderStream(ctx, v, out)
+	wg.Wait()
+
+	if err == nil {
+		t.Fatal("expected RenderStream to return an error after cancellation")
+	}
+}
+
+func TestRenderStreamReportsProgress(t *testing.T) {
+	v := Viewport{Scale: 2, Width: 64, Height: 64, MaxIter: 50}
+	r := &Renderer{TileSize: 32}
+
+	var mu sync.Mutex
+	var lastCompleted, lastTotal int
+	r.Progress = func(completed, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		lastCompleted, lastTotal = completed, total
+	}
+
+	out := make(chan Tile)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range out {
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := r.RenderStream(ctx, v, out); err != nil {
+		t.Fatalf("RenderStream: %v", err)
+	}
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if lastCompleted != lastTotal || lastTotal == 0 {
+		t.Fatalf("progress = %d/%d, want fully complete", lastCompleted, lastTotal)
+	}
+}
@@ -0,0 +1,142 @@
+package render
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"math/big"
+
+	"github.com/stasexx/go-mandelbrot/pkg/palette"
+)
+
+// DeepZoomThreshold is the Scale below which Render switches its inner
+// iteration from float64 to math/big.Float arithmetic. Below this scale,
+// the difference between adjacent pixels' coordinates falls below
+// float64's representable precision and the image collapses to noise.
+const DeepZoomThreshold = 1e-14
+
+// DefaultPrecision is the math/big.Float mantissa precision, in bits,
+// used for deep zooms when Viewport.Precision is zero.
+const DefaultPrecision = 256
+
+// Viewport describes the region of the complex plane to render and the
+// image resolution to render it at, replacing the single hard-coded
+// viewport the original renderer used.
+type Viewport struct {
+	// CenterX, CenterY are the complex-plane coordinates at the center
+	// of the image.
+	CenterX, CenterY float64
+
+	// Scale is the half-width of the rendered region, in complex-plane
+	// units: the real axis spans [CenterX-Scale, CenterX+Scale]. Pixels
+	// are square, so the imaginary axis spans
+	// [CenterY-Scale*Height/Width, CenterY+Scale*Height/Width]; no
+	// aspect-ratio stretching is applied to either axis.
+	Scale float64
+
+	Width, Height int
+	MaxIter       int
+
+	// Precision is the math/big.Float mantissa precision, in bits, used
+	// when Scale is below DeepZoomThreshold. Zero selects
+	// DefaultPrecision. Ignored above DeepZoomThreshold.
+	Precision uint
+}
+
+func (v Viewport) precision() uint {
+	if v.Precision == 0 {
+		return DefaultPrecision
+	}
+	return v.Precision
+}
+
+// coordAt maps pixel (x, y) to its complex-plane coordinate under v.
+// Both axes are divided by Width, not their own dimension, so pixels are
+// square and non-square images don't come out stretched.
+func (v Viewport) coordAt(x, y int) (real, imag float64) {
+	real = v.CenterX + (float64(x)/float64(v.Width)-0.5)*2*v.Scale
+	imag = v.CenterY + (float64(y)/float64(v.Width)-float64(v.Height)/(2*float64(v.Width)))*2*v.Scale
+	return real, imag
+}
+
+// Render computes the Mandelbrot image described by v using a Renderer
+// with default worker-pool settings. It is a convenience wrapper for
+// callers that don't need to tune Workers or TileSize.
+func Render(v Viewport) *image.RGBA {
+	r := &Renderer{}
+	return r.Render(context.Background(), v)
+}
+
+func renderTileBig(img *image.RGBA, t tile, v Viewport, prec uint, pal palette.Palette) {
+	centerX := new(big.Float).SetPrec(prec).SetFloat64(v.CenterX)
+	centerY := new(big.Float).SetPrec(prec).SetFloat64(v.CenterY)
+	halfWidth := new(big.Float).SetPrec(prec).SetFloat64(float64(v.Width) / 2)
+	halfHeight := new(big.Float).SetPrec(prec).SetFloat64(float64(v.Height) / 2)
+
+	// unitsPerPixel is derived from Width alone so both axes use the
+	// same scale and pixels come out square, matching coordAt.
+	unitsPerPixel := new(big.Float).SetPrec(prec).SetFloat64(v.Scale)
+	unitsPerPixel.Mul(unitsPerPixel, big.NewFloat(2))
+	unitsPerPixel.Quo(unitsPerPixel, new(big.Float).SetPrec(prec).SetFloat64(float64(v.Width)))
+
+	real := new(big.Float).SetPrec(prec)
+	imag := new(big.Float).SetPrec(prec)
+	tmp := new(big.Float).SetPrec(prec)
+
+	for x := t.minX; x < t.maxX; x++ {
+		tmp.SetPrec(prec).SetFloat64(float64(x))
+		tmp.Sub(tmp, halfWidth)
+		tmp.Mul(tmp, unitsPerPixel)
+		real.Add(centerX, tmp)
+
+		for y := t.minY; y < t.maxY; y++ {
+			tmp.SetPrec(prec).SetFloat64(float64(y))
+			tmp.Sub(tmp, halfHeight)
+			tmp.Mul(tmp, unitsPerPixel)
+			imag.Add(centerY, tmp)
+
+			img.Set(x, y, mandelbrotBigAt(real, imag, v.MaxIter, prec, pal))
+		}
+	}
+}
+
+// mandelbrotBigAt computes the color of the point (real, imag) in the
+// complex plane under pal, using math/big.Float arithmetic at the given
+// precision, for use at scales where float64 loses resolution.
+func mandelbrotBigAt(real, imag *big.Float, maxIter int, prec uint, pal palette.Palette) color.Color {
+	zr := new(big.Float).SetPrec(prec)
+	zi := new(big.Float).SetPrec(prec)
+	zr2 := new(big.Float).SetPrec(prec)
+	zi2 := new(big.Float).SetPrec(prec)
+	mag := new(big.Float).SetPrec(prec)
+	nextZr := new(big.Float).SetPrec(prec)
+	nextZi := new(big.Float).SetPrec(prec)
+	four := big.NewFloat(4)
+	two := big.NewFloat(2)
+
+	for i := 0; i < maxIter; i++ {
+		zr2.Mul(zr, zr)
+		zi2.Mul(zi, zi)
+		mag.Add(zr2, zi2)
+		if mag.Cmp(four) > 0 {
+			// z here is z_i; iterateAt counts the step that produced it
+			// as i-1, so report the same count.
+			zrf, _ := zr.Float64()
+			zif, _ := zi.Float64()
+			return pal.ColorAt(i-1, zrf, zif, maxIter)
+		}
+
+		nextZr.Sub(zr2, zi2)
+		nextZr.Add(nextZr, real)
+
+		nextZi.Mul(zr, zi)
+		nextZi.Mul(nextZi, two)
+		nextZi.Add(nextZi, imag)
+
+		zr.Set(nextZr)
+		zi.Set(nextZi)
+	}
+	zrf, _ := zr.Float64()
+	zif, _ := zi.Float64()
+	return pal.ColorAt(maxIter, zrf, zif, maxIter)
+}
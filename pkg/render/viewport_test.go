@@ -0,0 +1,110 @@
+package render
+
+import (
+	"context"
+	"hash/fnv"
+	"image"
+	"testing"
+)
+
+func checksum(img *image.RGBA) uint64 {
+	h := fnv.New64a()
+	h.Write(img.Pix)
+	return h.Sum64()
+}
+
+func TestRenderSnapshotAtKnownCoordinates(t *testing.T) {
+	cases := []struct {
+		name string
+		v    Viewport
+		want uint64
+	}{
+		{
+			name: "default view",
+			v:    Viewport{CenterX: 0, CenterY: 0, Scale: 2, Width: 40, Height: 40, MaxIter: 50},
+			want: 16261829290212593575,
+		},
+		{
+			name: "seahorse valley",
+			v:    Viewport{CenterX: -0.75, CenterY: 0.1, Scale: 0.05, Width: 40, Height: 40, MaxIter: 256},
+			want: 10705421173782077184,
+		},
+		{
+			name: "elephant valley",
+			v:    Viewport{CenterX: 0.275, CenterY: 0, Scale: 0.02, Width: 40, Height: 40, MaxIter: 256},
+			want: 2075477432511527627,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := checksum(Render(tc.v))
+			if got != tc.want {
+				t.Errorf("%s: checksum = %d, want %d (image changed)", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCoordAtUsesSquarePixels(t *testing.T) {
+	// Width and Height are independent (e.g. -w/-h on the CLI), so a
+	// wider-than-tall viewport must not stretch the imaginary axis: a
+	// step of one pixel must cover the same complex-plane distance on
+	// both axes.
+	v := Viewport{CenterX: 0, CenterY: 0, Scale: 2, Width: 80, Height: 40}
+
+	x0, _ := v.coordAt(0, 0)
+	x1, _ := v.coordAt(1, 0)
+	xStep := x1 - x0
+
+	_, y0 := v.coordAt(0, 0)
+	_, y1 := v.coordAt(0, 1)
+	yStep := y1 - y0
+
+	if xStep != yStep {
+		t.Fatalf("pixel steps differ: x step = %v, y step = %v, want equal (square pixels)", xStep, yStep)
+	}
+}
+
+func TestDeepZoomSwitchesToBigFloat(t *testing.T) {
+	// -2+0i is the tip of the main spike: a boundary point whose
+	// neighborhood keeps fine escape-time structure at arbitrary zoom
+	// depth, and one of the few coordinates exactly representable in
+	// float64, so any loss of detail below is purely a precision
+	// artifact of the pixel-coordinate math, not the sampled location.
+	//
+	// At this scale, adjacent pixel coordinates computed in float64
+	// collapse onto the same value, which is exactly the precision loss
+	// this feature works around.
+	v := Viewport{
+		CenterX: -2,
+		CenterY: 0,
+		Scale:   1e-16,
+		Width:   20,
+		Height:  20,
+		MaxIter: 2000,
+	}
+
+	r0x, _ := v.coordAt(0, 0)
+	r1x, _ := v.coordAt(1, 0)
+	if r0x != r1x {
+		t.Fatalf("expected float64 coordinates to collapse at this scale, got %v != %v", r0x, r1x)
+	}
+
+	img := (&Renderer{}).Render(context.Background(), v)
+
+	first := img.At(0, 0)
+	allSame := true
+	bounds := img.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X && allSame; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if img.At(x, y) != first {
+				allSame = false
+				break
+			}
+		}
+	}
+	if allSame {
+		t.Fatal("deep-zoom render collapsed to a single color; big.Float path did not resolve detail")
+	}
+}